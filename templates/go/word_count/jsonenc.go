@@ -0,0 +1,10 @@
+package main
+
+// marshal and unmarshal back --stream mode's per-line Input/Output
+// encode/decode — the dominant cost in that mode, since every line pays
+// for one of each. The default implementation (jsonenc_segmentio.go)
+// uses github.com/segmentio/encoding/json for lower allocations on
+// large NDJSON workloads; build with -tags stdlibjson to fall back to
+// encoding/json (jsonenc_stdlib.go). Schema validation's generic
+// interface{} decode deliberately stays on encoding/json regardless of
+// this build tag; see schema.go.
@@ -0,0 +1,15 @@
+//go:build !stdlibjson
+
+package main
+
+import (
+	json "github.com/segmentio/encoding/json"
+)
+
+func marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,13 @@
+//go:build stdlibjson
+
+package main
+
+import "encoding/json"
+
+func marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
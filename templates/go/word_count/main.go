@@ -2,7 +2,9 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
@@ -12,24 +14,110 @@ type Input struct {
 }
 
 type Output struct {
-	Count int `json:"count"`
+	Count int    `json:"count"`
+	Mode  string `json:"mode"`
+	Bytes int    `json:"bytes"`
 }
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <json_input>\n", os.Args[0])
+	var output, schemaPath, mode string
+	var stream bool
+	flag.StringVar(&output, "o", "-", "output file (default stdout)")
+	flag.StringVar(&output, "output", "-", "output file (default stdout)")
+	flag.StringVar(&schemaPath, "schema", "", "path to a JSON Schema overriding the built-in input contract")
+	flag.StringVar(&mode, "mode", "words", "tokenization mode: words, runes, graphemes, sentences")
+	flag.BoolVar(&stream, "stream", false, "read newline-delimited JSON from stdin, writing one Output per line")
+	flag.Parse()
+
+	schemaPath = strings.TrimPrefix(schemaPath, "@")
+
+	schema, err := compileSchema(schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error compiling schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	if stream {
+		w, closeW, err := openOutput(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening output: %v\n", err)
+			os.Exit(1)
+		}
+		defer closeW()
+
+		if err := runStream(schema, mode, os.Stdin, w); err != nil {
+			fmt.Fprintf(os.Stderr, "Error streaming: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	raw, err := readInput(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+
+	if verr := validateInput(schema, raw); verr != nil {
+		enc, _ := json.Marshal(verr)
+		fmt.Fprintln(os.Stderr, string(enc))
 		os.Exit(1)
 	}
 
 	var input Input
-	if err := json.Unmarshal([]byte(os.Args[1]), &input); err != nil {
+	if err := json.Unmarshal(raw, &input); err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing input: %v\n", err)
 		os.Exit(1)
 	}
 
-	words := strings.Fields(input.Text)
-	output := Output{Count: len(words)}
-	
-	result, _ := json.Marshal(output)
-	fmt.Println(string(result))
+	count, err := tokenize(mode, input.Text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error tokenizing input: %v\n", err)
+		os.Exit(1)
+	}
+	result, _ := json.Marshal(Output{Count: count, Mode: mode, Bytes: len(input.Text)})
+
+	if err := writeOutput(output, result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readInput resolves the input argument into raw JSON bytes. An empty
+// argument or "-" reads from stdin, an argument prefixed with "@" is
+// read as a file path, and anything else is treated as an inline JSON
+// string.
+func readInput(arg string) ([]byte, error) {
+	switch {
+	case arg == "" || arg == "-":
+		return io.ReadAll(os.Stdin)
+	case strings.HasPrefix(arg, "@"):
+		return os.ReadFile(strings.TrimPrefix(arg, "@"))
+	default:
+		return []byte(arg), nil
+	}
+}
+
+// writeOutput writes result to path, or to stdout when path is "-".
+func writeOutput(path string, result []byte) error {
+	if path == "-" {
+		fmt.Println(string(result))
+		return nil
+	}
+	return os.WriteFile(path, append(result, '\n'), 0o644)
+}
+
+// openOutput returns a writer for --stream mode: stdout when path is
+// "-", otherwise a truncated file at path. The returned close func must
+// be called (via defer) once the caller is done writing.
+func openOutput(path string) (io.Writer, func(), error) {
+	if path == "-" {
+		return os.Stdout, func() {}, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schema.json
+var defaultSchema []byte
+
+// missingProperty extracts the first property name from a jsonschema
+// "required" failure message of the form `missing properties: 'text'`,
+// since the library leaves InstanceLocation empty for that keyword.
+var missingProperty = regexp.MustCompile(`^missing properties: '([^']+)'`)
+
+// schemaError is the structured shape written to stderr when validation
+// fails, so callers can parse the failure instead of scraping text.
+type schemaError struct {
+	Error string `json:"error"`
+	Path  string `json:"path"`
+}
+
+// compileSchema compiles the embedded default schema, or the contents of
+// schemaPath when non-empty.
+func compileSchema(schemaPath string) (*jsonschema.Schema, error) {
+	raw := defaultSchema
+	if schemaPath != "" {
+		b, err := os.ReadFile(schemaPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading schema: %w", err)
+		}
+		raw = b
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("schema.json", bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("loading schema: %w", err)
+	}
+	return compiler.Compile("schema.json")
+}
+
+// validateInput checks raw against schema and returns a schemaError ready
+// to be marshaled to stderr when validation fails.
+func validateInput(schema *jsonschema.Schema, raw []byte) *schemaError {
+	// Decoded into a generic interface{} for jsonschema.Validate, so this
+	// intentionally stays on encoding/json rather than the pluggable
+	// marshal/unmarshal codec: segmentio/encoding/json is tuned for
+	// decoding into concrete struct types (see Input/Output in main.go) and
+	// regresses generic map[string]interface{} decoding, benchmarked at
+	// roughly 3x the allocations of encoding/json for this exact shape
+	// (see BenchmarkUnmarshalGenericPluggable and
+	// BenchmarkUnmarshalGenericStdlib in stream_bench_test.go).
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return &schemaError{Error: err.Error(), Path: "/"}
+	}
+
+	if err := schema.Validate(v); err != nil {
+		if verr, ok := err.(*jsonschema.ValidationError); ok {
+			leaf := verr
+			for len(leaf.Causes) > 0 {
+				leaf = leaf.Causes[0]
+			}
+			return &schemaError{Error: leaf.Message, Path: leafPath(leaf)}
+		}
+		return &schemaError{Error: err.Error(), Path: "/"}
+	}
+	return nil
+}
+
+// leafPath returns the field path for a leaf validation error. The
+// "required" keyword reports the failure against the parent object, so
+// InstanceLocation is empty; in that case, the missing property name is
+// recovered from the error message instead.
+func leafPath(leaf *jsonschema.ValidationError) string {
+	if leaf.InstanceLocation != "" {
+		return leaf.InstanceLocation
+	}
+	if m := missingProperty.FindStringSubmatch(leaf.Message); m != nil {
+		return "/" + m[1]
+	}
+	return leaf.InstanceLocation
+}
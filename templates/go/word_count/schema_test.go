@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestValidateInputDefaultSchema(t *testing.T) {
+	schema, err := compileSchema("")
+	if err != nil {
+		t.Fatalf("compileSchema(\"\") failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		raw      string
+		wantErr  bool
+		wantPath string
+	}{
+		{name: "valid text", raw: `{"text":"hello"}`},
+		{name: "missing text", raw: `{}`, wantErr: true, wantPath: "/text"},
+		{name: "empty text violates minLength", raw: `{"text":""}`, wantErr: true, wantPath: "/text"},
+		{name: "wrong type for text", raw: `{"text":5}`, wantErr: true, wantPath: "/text"},
+		{name: "additional property rejected", raw: `{"text":"hi","extra":1}`, wantErr: true, wantPath: ""},
+		{name: "malformed json", raw: `{"text":`, wantErr: true, wantPath: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verr := validateInput(schema, []byte(tt.raw))
+			if !tt.wantErr {
+				if verr != nil {
+					t.Fatalf("validateInput(%q) = %+v, want nil", tt.raw, verr)
+				}
+				return
+			}
+			if verr == nil {
+				t.Fatalf("validateInput(%q) = nil, want error", tt.raw)
+			}
+			if verr.Error == "" {
+				t.Errorf("validateInput(%q).Error is empty", tt.raw)
+			}
+			if verr.Path != tt.wantPath {
+				t.Errorf("validateInput(%q).Path = %q, want %q", tt.raw, verr.Path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestCompileSchemaOverride(t *testing.T) {
+	dir := t.TempDir()
+	overridePath := dir + "/schema.json"
+	override := `{
+		"type": "object",
+		"properties": {"text": {"type": "string"}},
+		"required": ["text", "lang"]
+	}`
+	if err := os.WriteFile(overridePath, []byte(override), 0o644); err != nil {
+		t.Fatalf("writing override schema: %v", err)
+	}
+
+	schema, err := compileSchema(overridePath)
+	if err != nil {
+		t.Fatalf("compileSchema(%q) failed: %v", overridePath, err)
+	}
+
+	if verr := validateInput(schema, []byte(`{"text":"hi"}`)); verr == nil {
+		t.Fatalf("expected override schema to require \"lang\", got no error")
+	}
+	if verr := validateInput(schema, []byte(`{"text":"hi","lang":"en"}`)); verr != nil {
+		t.Fatalf("expected override schema to accept text+lang, got %+v", verr)
+	}
+}
+
+func TestCompileSchemaMissingOverrideFile(t *testing.T) {
+	if _, err := compileSchema("/nonexistent/schema.json"); err == nil {
+		t.Fatal("expected error for missing schema file, got nil")
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// runStream reads newline-delimited JSON Input objects from r and
+// writes one Output per line to w, so callers can process large batches
+// of documents without paying one process-invocation per document.
+func runStream(schema *jsonschema.Schema, mode string, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		if verr := validateInput(schema, line); verr != nil {
+			enc, _ := marshal(verr)
+			fmt.Fprintln(os.Stderr, string(enc))
+			continue
+		}
+
+		var input Input
+		if err := unmarshal(line, &input); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing line: %v\n", err)
+			continue
+		}
+
+		count, err := tokenize(mode, input.Text)
+		if err != nil {
+			return err
+		}
+
+		result, err := marshal(Output{Count: count, Mode: mode, Bytes: len(input.Text)})
+		if err != nil {
+			return err
+		}
+
+		if _, err := bw.Write(result); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// ndjsonFixture builds n lines of {"text": "..."} JSON, representative
+// of the --stream mode hot path.
+func ndjsonFixture(n int) [][]byte {
+	lines := make([][]byte, n)
+	for i := range lines {
+		lines[i] = []byte(fmt.Sprintf(`{"text":"the quick brown fox jumps over the lazy dog %d"}`, i))
+	}
+	return lines
+}
+
+func BenchmarkMarshalOutput(b *testing.B) {
+	out := Output{Count: 9, Mode: "words", Bytes: 42}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := marshal(out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalInput1M(b *testing.B) {
+	lines := ndjsonFixture(1_000_000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var input Input
+		line := lines[i%len(lines)]
+		if err := unmarshal(line, &input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUnmarshalGenericPluggable and BenchmarkUnmarshalGenericStdlib
+// compare the two codecs when decoding into interface{} rather than a
+// concrete struct, the shape schema.validateInput needs for
+// jsonschema.Validate. segmentio/encoding/json is tuned for struct
+// decoding and measurably regresses on this shape, which is why
+// validateInput stays on encoding/json regardless of the stdlibjson
+// build tag; see schema.go.
+func BenchmarkUnmarshalGenericPluggable(b *testing.B) {
+	lines := ndjsonFixture(1_000_000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var v interface{}
+		line := lines[i%len(lines)]
+		if err := unmarshal(line, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalGenericStdlib(b *testing.B) {
+	lines := ndjsonFixture(1_000_000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var v interface{}
+		line := lines[i%len(lines)]
+		if err := json.Unmarshal(line, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRunStream1M(b *testing.B) {
+	lines := ndjsonFixture(1_000_000)
+	var buf bytes.Buffer
+	for _, l := range lines {
+		buf.Write(l)
+		buf.WriteByte('\n')
+	}
+	fixture := buf.Bytes()
+
+	schema, err := compileSchema("")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var out bytes.Buffer
+		if err := runStream(schema, "words", bytes.NewReader(fixture), &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRunStream(t *testing.T) {
+	schema, err := compileSchema("")
+	if err != nil {
+		t.Fatalf("compileSchema(\"\") failed: %v", err)
+	}
+
+	input := strings.Join([]string{
+		`{"text":"one two three"}`,
+		``,                         // blank lines are skipped
+		`{"text":"not valid json`,  // malformed JSON line, skipped not fatal
+		`{"extra":"fails schema"}`, // fails schema validation, skipped not fatal
+		`{"text":"four five"}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := runStream(schema, "words", strings.NewReader(input), &out); err != nil {
+		t.Fatalf("runStream returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d output lines, want 2 (malformed/invalid lines should be skipped): %q", len(lines), out.String())
+	}
+
+	var first, second Output
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("unmarshaling first output line: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("unmarshaling second output line: %v", err)
+	}
+
+	if first.Count != 3 || first.Mode != "words" {
+		t.Errorf("first output = %+v, want Count=3 Mode=words", first)
+	}
+	if second.Count != 2 || second.Mode != "words" {
+		t.Errorf("second output = %+v, want Count=2 Mode=words", second)
+	}
+}
+
+func TestRunStreamUnknownModePropagatesError(t *testing.T) {
+	schema, err := compileSchema("")
+	if err != nil {
+		t.Fatalf("compileSchema(\"\") failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = runStream(schema, "paragraphs", strings.NewReader(`{"text":"hi"}`), &out)
+	if err == nil {
+		t.Fatal("expected runStream to propagate an unknown-mode error, got nil")
+	}
+}
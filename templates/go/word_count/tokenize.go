@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// sentenceBoundary splits on runs of sentence-final punctuation followed
+// by whitespace, e.g. "Hi there. Bye!" -> ["Hi there", "Bye!"] (the
+// matched punctuation and whitespace are dropped).
+var sentenceBoundary = regexp.MustCompile(`[.!?]+\s+`)
+
+// tokenize counts units of text according to mode: "words" (the
+// historical strings.Fields behavior), "runes" (Unicode code points),
+// "graphemes" (user-perceived characters), or "sentences".
+func tokenize(mode, text string) (int, error) {
+	switch mode {
+	case "", "words":
+		return len(strings.Fields(text)), nil
+	case "runes":
+		return utf8.RuneCountInString(text), nil
+	case "graphemes":
+		return countGraphemes(text), nil
+	case "sentences":
+		return countSentences(text), nil
+	default:
+		return 0, fmt.Errorf("unknown mode %q", mode)
+	}
+}
+
+// countGraphemes counts user-perceived characters per UAX #29. Input is
+// normalized to NFC first so combining-mark sequences that differ only
+// by normalization form (e.g. precomposed vs. decomposed accents)
+// cluster the same way before segmentation.
+func countGraphemes(text string) int {
+	return uniseg.GraphemeClusterCount(norm.NFC.String(text))
+}
+
+// countSentences splits the trimmed text on sentenceBoundary and counts
+// the non-empty fragments. A final punctuation mark has no following
+// whitespace after trimming, so it does not split; an empty fragment
+// arises only when the text starts with a boundary (e.g. ". Hi").
+func countSentences(text string) int {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return 0
+	}
+
+	parts := sentenceBoundary.Split(trimmed, -1)
+	count := 0
+	for _, p := range parts {
+		if strings.TrimSpace(p) != "" {
+			count++
+		}
+	}
+	return count
+}
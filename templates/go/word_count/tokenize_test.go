@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestTokenize(t *testing.T) {
+	const (
+		familyZWJEmoji  = "\U0001F468‍\U0001F469‍\U0001F467‍\U0001F466" // single grapheme, 7 runes
+		usFlagRegional  = "\U0001F1FA\U0001F1F8"                        // single grapheme, 2 runes
+		eWithComboAcute = "é"                                          // single grapheme, 2 runes
+	)
+
+	tests := []struct {
+		name    string
+		mode    string
+		text    string
+		want    int
+		wantErr bool
+	}{
+		{name: "words default mode", mode: "", text: "the quick brown fox", want: 4},
+		{name: "words explicit mode", mode: "words", text: "  a  b   c ", want: 3},
+		{name: "words empty text", mode: "words", text: "", want: 0},
+		{name: "runes ascii", mode: "runes", text: "abc", want: 3},
+		{name: "runes multibyte", mode: "runes", text: "héllo", want: 5},
+		{name: "graphemes combining mark", mode: "graphemes", text: eWithComboAcute, want: 1},
+		{name: "graphemes ZWJ family emoji", mode: "graphemes", text: familyZWJEmoji, want: 1},
+		{name: "graphemes regional indicator flag", mode: "graphemes", text: usFlagRegional, want: 1},
+		{name: "sentences basic", mode: "sentences", text: "Hi there. Bye!", want: 2},
+		{name: "sentences trailing punctuation no text", mode: "sentences", text: "One sentence.", want: 1},
+		{name: "sentences empty text", mode: "sentences", text: "   ", want: 0},
+		{name: "unknown mode errors", mode: "paragraphs", text: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tokenize(tt.mode, tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("tokenize(%q, %q) = %d, nil; want error", tt.mode, tt.text, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("tokenize(%q, %q) unexpected error: %v", tt.mode, tt.text, err)
+			}
+			if got != tt.want {
+				t.Errorf("tokenize(%q, %q) = %d, want %d", tt.mode, tt.text, got, tt.want)
+			}
+		})
+	}
+}